@@ -0,0 +1,89 @@
+package astar
+
+import "math"
+
+// AllPairs runs Floyd-Warshall over the given node set, building the
+// initial adjacency from PathNeighbors/PathNeighborCost, and returns the
+// full distance matrix plus a next-hop table for path reconstruction via
+// ReconstructPath. ok is false if a negative cycle was detected among
+// nodes, in which case dist and next are not meaningful.
+//
+// This is for callers who need repeated queries between many source/target
+// pairs drawn from a fixed node set - precomputing NPC travel tables, or
+// betweenness/closeness centrality on a graph modeled with Pather - and
+// would otherwise have to run A* once per pair.
+func AllPairs(ctx Context, nodes []Pather) (dist map[Pather]map[Pather]float64, next map[Pather]map[Pather]Pather, ok bool) {
+	inSet := make(map[Pather]bool, len(nodes))
+	for _, n := range nodes {
+		inSet[n] = true
+	}
+
+	dist = make(map[Pather]map[Pather]float64, len(nodes))
+	next = make(map[Pather]map[Pather]Pather, len(nodes))
+	for _, u := range nodes {
+		dist[u] = make(map[Pather]float64, len(nodes))
+		next[u] = make(map[Pather]Pather, len(nodes))
+		for _, v := range nodes {
+			if u == v {
+				dist[u][v] = 0
+			} else {
+				dist[u][v] = math.Inf(1)
+			}
+		}
+	}
+
+	buf := make([]Pather, 0, 8)
+	for _, u := range nodes {
+		for _, v := range u.PathNeighbors(ctx, buf[:0]) {
+			if !inSet[v] {
+				continue
+			}
+			if cost := u.PathNeighborCost(ctx, v); cost < dist[u][v] {
+				dist[u][v] = cost
+				next[u][v] = v
+			}
+		}
+	}
+
+	for _, k := range nodes {
+		for _, i := range nodes {
+			dik := dist[i][k]
+			if math.IsInf(dik, 1) {
+				continue
+			}
+			for _, j := range nodes {
+				if d := dik + dist[k][j]; d < dist[i][j] {
+					dist[i][j] = d
+					next[i][j] = next[i][k]
+				}
+			}
+		}
+	}
+
+	for _, v := range nodes {
+		if dist[v][v] < 0 {
+			return dist, next, false
+		}
+	}
+
+	return dist, next, true
+}
+
+// ReconstructPath rebuilds the path from "from" to "to" using the next-hop
+// table produced by AllPairs. It returns nil if there's no known path.
+func ReconstructPath(next map[Pather]map[Pather]Pather, from, to Pather) []Pather {
+	if from != to {
+		if _, ok := next[from][to]; !ok {
+			return nil
+		}
+	} else if _, ok := next[from]; !ok {
+		return nil
+	}
+
+	path := []Pather{from}
+	for from != to {
+		from = next[from][to]
+		path = append(path, from)
+	}
+	return path
+}
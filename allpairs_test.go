@@ -0,0 +1,86 @@
+package astar
+
+import "testing"
+
+type anode struct {
+	id    string
+	edges map[string]float64
+	all   map[string]*anode
+}
+
+func (n *anode) PathNeighbors(ctx Context, buf []Pather) []Pather {
+	for id := range n.edges {
+		buf = append(buf, n.all[id])
+	}
+	return buf
+}
+func (n *anode) PathNeighborCost(ctx Context, to Pather) float64 {
+	return n.edges[to.(*anode).id]
+}
+func (n *anode) PathEstimatedCost(ctx Context, to Pather) float64 {
+	return 0
+}
+
+func newAGraph(edges map[string]map[string]float64) map[string]*anode {
+	all := map[string]*anode{}
+	for id := range edges {
+		all[id] = &anode{id: id, edges: map[string]float64{}, all: all}
+	}
+	for from, tos := range edges {
+		for to, cost := range tos {
+			if _, ok := all[to]; !ok {
+				all[to] = &anode{id: to, edges: map[string]float64{}, all: all}
+			}
+			all[from].edges[to] = cost
+		}
+	}
+	return all
+}
+
+func TestAllPairsMatchesPath(t *testing.T) {
+	g := newAGraph(map[string]map[string]float64{
+		"a": {"b": 1, "c": 4},
+		"b": {"c": 1, "d": 5},
+		"c": {"d": 1},
+		"d": {},
+	})
+
+	nodes := make([]Pather, 0, len(g))
+	for _, n := range g {
+		nodes = append(nodes, n)
+	}
+
+	dist, next, ok := AllPairs(nil, nodes)
+	if !ok {
+		t.Fatalf("expected no negative cycle")
+	}
+
+	for _, from := range []string{"a", "b", "c", "d"} {
+		for _, to := range []string{"a", "b", "c", "d"} {
+			_, wantDist, wantFound := Path(g[from], g[to])
+			gotDist := dist[g[from]][g[to]]
+
+			if wantFound && gotDist != wantDist {
+				t.Fatalf("%s->%s: AllPairs dist=%v Path dist=%v", from, to, gotDist, wantDist)
+			}
+
+			path := ReconstructPath(next, g[from], g[to])
+			if wantFound && from != to && len(path) == 0 {
+				t.Fatalf("%s->%s: expected reconstructed path", from, to)
+			}
+		}
+	}
+}
+
+func TestAllPairsDetectsNegativeCycle(t *testing.T) {
+	g := newAGraph(map[string]map[string]float64{
+		"a": {"b": 1},
+		"b": {"a": -2},
+	})
+	nodes := []Pather{g["a"], g["b"]}
+
+	_, _, ok := AllPairs(nil, nodes)
+	if ok {
+		t.Fatalf("expected negative cycle to be detected")
+	}
+}
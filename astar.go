@@ -1,6 +1,9 @@
 package astar
 
-import "container/heap"
+import (
+	"container/heap"
+	"sync"
+)
 
 // astar is an A* pathfinding implementation.
 
@@ -34,6 +37,38 @@ type node struct {
 	index  int
 }
 
+// reset clears a pooled node so it can be reused to represent a different
+// Pather in a later search.
+func (n *node) reset(p Pather) {
+	n.pather = p
+	n.cost = 0
+	n.rank = 0
+	n.parent = nil
+	n.open = false
+	n.closed = false
+	n.index = 0
+}
+
+// peek returns the lowest-rank node without removing it from the queue.
+func (pq priorityQueue) peek() *node {
+	return pq[0]
+}
+
+// nodePool, nodeMapPool and queuePool let NewSearch reuse the allocations of
+// completed searches instead of paying for a fresh map and node graph every
+// time, which matters for callers re-pathing many entities per game tick.
+var (
+	nodePool = sync.Pool{
+		New: func() interface{} { return &node{} },
+	}
+	nodeMapPool = sync.Pool{
+		New: func() interface{} { return nodeMap{} },
+	}
+	queuePool = sync.Pool{
+		New: func() interface{} { return &priorityQueue{} },
+	}
+)
+
 // nodeMap is a collection of nodes keyed by Pather nodes for quick reference.
 type nodeMap map[Pather]*node
 
@@ -41,9 +76,8 @@ type nodeMap map[Pather]*node
 func (nm nodeMap) get(p Pather) *node {
 	n, ok := nm[p]
 	if !ok {
-		n = &node{
-			pather: p,
-		}
+		n = nodePool.Get().(*node)
+		n.reset(p)
 		nm[p] = n
 	}
 	return n
@@ -64,13 +98,24 @@ type Search struct {
 	to       Pather
 	ctx      Context
 	res      result
+
+	budgeted bool
+	maxCost  float64
+	best     *node
+	bestDist float64
+
+	weight        float64
+	maxExpansions int
+	expansions    int
 }
 
-// NewSearch creates a new search object.
+// NewSearch creates a new search object. Its nodeMap, priority queue and
+// nodes are drawn from package-scope pools; call Release once the result has
+// been consumed to return them.
 func NewSearch(ctx Context, from, to Pather) *Search {
 	s := &Search{}
-	s.nm = nodeMap{}
-	s.nq = &priorityQueue{}
+	s.nm = nodeMapPool.Get().(nodeMap)
+	s.nq = queuePool.Get().(*priorityQueue)
 	s.tmp = make([]Pather, 0, 8)
 	heap.Init(s.nq)
 	s.fromNode = s.nm.get(from)
@@ -78,9 +123,33 @@ func NewSearch(ctx Context, from, to Pather) *Search {
 	heap.Push(s.nq, s.fromNode)
 	s.to = to
 	s.ctx = ctx
+	s.best = s.fromNode
+	s.bestDist = from.PathEstimatedCost(ctx, to)
+	s.weight = 1
 	return s
 }
 
+// Release returns the search's nodeMap, priority queue and nodes to their
+// package pools so a later search can reuse them instead of allocating
+// fresh ones. Call it once Result (or Step until done) has been consumed;
+// the search must not be used afterwards.
+func (s *Search) Release() {
+	if s.nm == nil {
+		return
+	}
+
+	for p, n := range s.nm {
+		nodePool.Put(n)
+		delete(s.nm, p)
+	}
+	nodeMapPool.Put(s.nm)
+	s.nm = nil
+
+	*s.nq = (*s.nq)[:0]
+	queuePool.Put(s.nq)
+	s.nq = nil
+}
+
 // PathWithContext calculates a short path and the distance between the two Pather nodes.
 // ctx is user optional data.
 // If no path is found, found will be false.
@@ -88,7 +157,9 @@ func PathWithContext(ctx Context, from, to Pather) (path []Pather, distance floa
 	s := NewSearch(ctx, from, to)
 	for !s.Step() {
 	}
-	return s.Result()
+	path, distance, found = s.Result()
+	s.Release()
+	return
 }
 
 // Path calculates a short path and the distance between the two Pather nodes.
@@ -96,6 +167,23 @@ func Path(from, to Pather) (path []Pather, distance float64, found bool) {
 	return PathWithContext(nil, from, to)
 }
 
+// PathWithBudget calculates a path the same way as PathWithContext, but gives
+// up once every open node's rank exceeds maxCost. If the goal can't be
+// reached within budget, the returned path leads to the node with the
+// lowest heuristic distance to the goal that was reached (opened) during
+// the search instead of an empty path, so a caller can still walk as close as
+// possible (e.g. a click on an unreachable tile).
+func PathWithBudget(ctx Context, from, to Pather, maxCost float64) (path []Pather, distance float64, found bool) {
+	s := NewSearch(ctx, from, to)
+	s.budgeted = true
+	s.maxCost = maxCost
+	for !s.Step() {
+	}
+	path, distance, found = s.Result()
+	s.Release()
+	return
+}
+
 // Result retrives the final search result.
 func (s *Search) Result() (path []Pather, distance float64, found bool) {
 	for !s.Step() {
@@ -110,24 +198,37 @@ func (s *Search) Step() bool {
 	if s.res.done || s.nq.Len() == 0 {
 		// There's no path or we are already done.
 		s.res.done = true
+		if s.budgeted && !s.res.found {
+			s.res.path, s.res.distance = pathTo(s.best)
+		}
 		return s.res.done
 	}
 
+	if s.budgeted && s.nq.peek().rank > s.maxCost {
+		// Every remaining open node is beyond budget; settle for the
+		// closest node to the goal that was reached so far.
+		s.res.done = true
+		s.res.path, s.res.distance = pathTo(s.best)
+		return true
+	}
+
+	if s.maxExpansions > 0 && s.expansions >= s.maxExpansions {
+		// Expansion budget exhausted; settle for the closest node to
+		// the goal that was reached so far.
+		s.res.done = true
+		s.res.path, s.res.distance = pathTo(s.best)
+		return true
+	}
+
 	current := heap.Pop(s.nq).(*node)
+	s.expansions++
 	current.open = false
 	current.closed = true
 
 	if current == s.nm.get(s.to) {
 		// Found a path to the goal.
-		p := []Pather{}
-		curr := current
-		for curr != nil {
-			p = append(p, curr.pather)
-			curr = curr.parent
-		}
-
-		s.res.path = p
-		s.res.distance = current.cost
+		s.res.done = true
+		s.res.path, s.res.distance = pathTo(current)
 		s.res.found = true
 
 		return true
@@ -144,18 +245,45 @@ func (s *Search) Step() bool {
 			neighborNode.closed = false
 		}
 		if !neighborNode.open && !neighborNode.closed {
+			estimate := neighbor.PathEstimatedCost(s.ctx, s.to)
+
 			neighborNode.cost = cost
 			neighborNode.open = true
-			neighborNode.rank = cost + neighbor.PathEstimatedCost(s.ctx, s.to)
+			neighborNode.rank = cost + s.weight*estimate
 			neighborNode.parent = current
 			heap.Push(s.nq, neighborNode)
+
+			if estimate < s.bestDist {
+				s.bestDist = estimate
+				s.best = neighborNode
+			}
 		}
 	}
 
 	return false
 }
 
+// pathTo walks the parent chain from n back to the start node, producing
+// the path and its total cost.
+func pathTo(n *node) ([]Pather, float64) {
+	p := []Pather{}
+	curr := n
+	for curr != nil {
+		p = append(p, curr.pather)
+		curr = curr.parent
+	}
+	return p, n.cost
+}
+
 // Context returns the users search context.
 func (s *Search) Context() Context {
 	return s.ctx
 }
+
+// Best returns the node with the lowest heuristic distance to the goal
+// seen during the search so far. It's most useful together with
+// PathWithBudget, or when driving Step manually and bailing out before a
+// full path is found.
+func (s *Search) Best() Pather {
+	return s.best.pather
+}
@@ -0,0 +1,221 @@
+package astar
+
+import (
+	"container/heap"
+	"math"
+)
+
+// ReversePather is an optional interface for nodes used in a
+// BidirectionalSearch. When a node implements it, PathPredecessors is used to
+// enumerate the nodes with an edge pointing into it while the search runs
+// backward from the goal. Nodes that don't implement it are assumed to sit
+// in an undirected graph, and PathNeighbors is reused instead.
+type ReversePather interface {
+	// PathPredecessors returns the direct neighboring nodes with an edge
+	// leading into this node.
+	PathPredecessors(ctx Context, buf []Pather) []Pather
+}
+
+// BidirectionalSearch is an object representing the current state of a
+// bidirectional A* search: one frontier expands forward from the start, one
+// expands backward from the goal, and the search stops once neither
+// frontier's lowest rank can beat the cheapest meeting point found. This detects unreachable goals and finds long paths through dense
+// graphs substantially faster than a single-direction Search.
+type BidirectionalSearch struct {
+	nmF, nmB nodeMap
+	nqF, nqB *priorityQueue
+	tmp      []Pather
+	from, to Pather
+	ctx      Context
+	res      result
+
+	forward bool
+	mu      float64
+	meetF   *node
+	meetB   *node
+}
+
+// NewBidirectionalSearch creates a new bidirectional search object. Like
+// NewSearch, its nodeMaps, priority queues and nodes are drawn from the
+// package-scope pools; call Release once the result has been consumed to
+// return them.
+func NewBidirectionalSearch(ctx Context, from, to Pather) *BidirectionalSearch {
+	s := &BidirectionalSearch{}
+	s.nmF = nodeMapPool.Get().(nodeMap)
+	s.nmB = nodeMapPool.Get().(nodeMap)
+	s.nqF = queuePool.Get().(*priorityQueue)
+	s.nqB = queuePool.Get().(*priorityQueue)
+	s.tmp = make([]Pather, 0, 8)
+	heap.Init(s.nqF)
+	heap.Init(s.nqB)
+
+	fromNode := s.nmF.get(from)
+	fromNode.open = true
+	fromNode.rank = from.PathEstimatedCost(ctx, to)
+	heap.Push(s.nqF, fromNode)
+
+	toNode := s.nmB.get(to)
+	toNode.open = true
+	toNode.rank = to.PathEstimatedCost(ctx, from)
+	heap.Push(s.nqB, toNode)
+
+	s.from = from
+	s.to = to
+	s.ctx = ctx
+	s.mu = math.Inf(1)
+	return s
+}
+
+// PathBidirectional calculates a short path and the distance between the two
+// Pather nodes using a bidirectional search.
+func PathBidirectional(ctx Context, from, to Pather) (path []Pather, distance float64, found bool) {
+	s := NewBidirectionalSearch(ctx, from, to)
+	for !s.Step() {
+	}
+	path, distance, found = s.Result()
+	s.Release()
+	return
+}
+
+// Release returns the search's nodeMaps, priority queues and nodes to their
+// package pools so a later search can reuse them instead of allocating
+// fresh ones. Call it once Result (or Step until done) has been consumed;
+// the search must not be used afterwards.
+func (s *BidirectionalSearch) Release() {
+	if s.nmF == nil {
+		return
+	}
+
+	for _, nm := range [2]nodeMap{s.nmF, s.nmB} {
+		for p, n := range nm {
+			nodePool.Put(n)
+			delete(nm, p)
+		}
+		nodeMapPool.Put(nm)
+	}
+	s.nmF, s.nmB = nil, nil
+
+	for _, nq := range [2]*priorityQueue{s.nqF, s.nqB} {
+		*nq = (*nq)[:0]
+		queuePool.Put(nq)
+	}
+	s.nqF, s.nqB = nil, nil
+}
+
+// Result retrieves the final search result.
+func (s *BidirectionalSearch) Result() (path []Pather, distance float64, found bool) {
+	for !s.Step() {
+	}
+	return s.res.path, s.res.distance, s.res.found
+}
+
+// Step advances the search by expanding one node, alternating between the
+// forward and backward frontier.
+//
+// Returns true if the search is done.
+func (s *BidirectionalSearch) Step() bool {
+	if s.res.done {
+		return true
+	}
+
+	if s.nqF.Len() == 0 || s.nqB.Len() == 0 || s.nqF.peek().rank >= s.mu || s.nqB.peek().rank >= s.mu {
+		// Either frontier ran dry, or the best f-value left in either
+		// frontier already can't beat the best meeting point found so
+		// far. Summing the two frontiers' f-values instead double-counts
+		// the heuristic and stops before the optimal meeting point is
+		// settled.
+		s.finish()
+		return true
+	}
+
+	if s.forward {
+		s.expand(s.nmF, s.nqF, s.nmB, s.to, false)
+	} else {
+		s.expand(s.nmB, s.nqB, s.nmF, s.from, true)
+	}
+	s.forward = !s.forward
+
+	return false
+}
+
+// expand pops the best node off nq and relaxes its neighbors (forward) or
+// predecessors (backward), updating the meeting cost mu whenever the popped
+// node has already been seen by the other frontier.
+func (s *BidirectionalSearch) expand(nm nodeMap, nq *priorityQueue, otherNm nodeMap, goal Pather, backward bool) {
+	current := heap.Pop(nq).(*node)
+	current.open = false
+	current.closed = true
+
+	if other, ok := otherNm[current.pather]; ok {
+		if meet := current.cost + other.cost; meet < s.mu {
+			s.mu = meet
+			if backward {
+				s.meetF, s.meetB = other, current
+			} else {
+				s.meetF, s.meetB = current, other
+			}
+		}
+	}
+
+	var adjacent []Pather
+	if backward {
+		if rp, ok := current.pather.(ReversePather); ok {
+			adjacent = rp.PathPredecessors(s.ctx, s.tmp[:0])
+		} else {
+			adjacent = current.pather.PathNeighbors(s.ctx, s.tmp[:0])
+		}
+	} else {
+		adjacent = current.pather.PathNeighbors(s.ctx, s.tmp[:0])
+	}
+
+	for _, next := range adjacent {
+		var cost float64
+		if backward {
+			// The edge runs next -> current, so next is the one
+			// that knows its cost.
+			cost = current.cost + next.PathNeighborCost(s.ctx, current.pather)
+		} else {
+			cost = current.cost + current.pather.PathNeighborCost(s.ctx, next)
+		}
+
+		nextNode := nm.get(next)
+		if cost < nextNode.cost {
+			if nextNode.open {
+				heap.Remove(nq, nextNode.index)
+			}
+			nextNode.open = false
+			nextNode.closed = false
+		}
+		if !nextNode.open && !nextNode.closed {
+			nextNode.cost = cost
+			nextNode.open = true
+			nextNode.rank = cost + next.PathEstimatedCost(s.ctx, goal)
+			nextNode.parent = current
+			heap.Push(nq, nextNode)
+		}
+	}
+}
+
+// finish assembles the result once the search has stopped, stitching the
+// path together from the two frontiers' meeting point.
+func (s *BidirectionalSearch) finish() {
+	s.res.done = true
+	if s.meetF == nil {
+		return
+	}
+
+	bp, _ := pathTo(s.meetB) // [meet, ..., to]
+	for i, j := 0, len(bp)-1; i < j; i, j = i+1, j-1 {
+		bp[i], bp[j] = bp[j], bp[i]
+	}
+	fp, _ := pathTo(s.meetF) // [meet, ..., from]
+
+	s.res.path = append(bp, fp[1:]...) // [to, ..., meet, ..., from]
+	s.res.distance = s.mu
+	s.res.found = true
+}
+
+// Context returns the users search context.
+func (s *BidirectionalSearch) Context() Context {
+	return s.ctx
+}
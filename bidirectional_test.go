@@ -0,0 +1,104 @@
+package astar
+
+import "testing"
+
+// gridNode is a simple 4-directional grid node used to exercise
+// BidirectionalSearch against a real (non-zero) admissible heuristic, which
+// is where the naive "sum of f-values" termination rule goes wrong.
+type gridNode struct {
+	x, y  int
+	walls map[[2]int]bool
+	all   map[[2]int]*gridNode
+}
+
+func (n *gridNode) PathNeighbors(ctx Context, buf []Pather) []Pather {
+	for _, d := range [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+		p := [2]int{n.x + d[0], n.y + d[1]}
+		if nb, ok := n.all[p]; ok && !n.walls[p] {
+			buf = append(buf, nb)
+		}
+	}
+	return buf
+}
+
+func (n *gridNode) PathNeighborCost(ctx Context, to Pather) float64 {
+	return 1
+}
+
+func (n *gridNode) PathEstimatedCost(ctx Context, to Pather) float64 {
+	o := to.(*gridNode)
+	dx, dy := n.x-o.x, n.y-o.y
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	return float64(dx + dy)
+}
+
+// newOpenGrid builds a size x size grid with no walls at all.
+func newOpenGrid(size int) map[[2]int]*gridNode {
+	all := make(map[[2]int]*gridNode, size*size)
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			all[[2]int{x, y}] = &gridNode{x: x, y: y, walls: map[[2]int]bool{}, all: all}
+		}
+	}
+	return all
+}
+
+// newWalledGrid builds a size x size grid with a wall blocking every
+// crossing from column wallX to column wallX+1 except a single gap at row
+// wallGapY, forcing any path between the two halves through that gap.
+func newWalledGrid(size, wallX, wallGapY int) map[[2]int]*gridNode {
+	all := make(map[[2]int]*gridNode, size*size)
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			all[[2]int{x, y}] = &gridNode{x: x, y: y, walls: map[[2]int]bool{}, all: all}
+		}
+	}
+	for y := 0; y < size; y++ {
+		if y == wallGapY {
+			continue
+		}
+		all[[2]int{wallX, y}].walls[[2]int{wallX + 1, y}] = true
+		all[[2]int{wallX + 1, y}].walls[[2]int{wallX, y}] = true
+	}
+	return all
+}
+
+func TestPathBidirectionalMatchesPath(t *testing.T) {
+	const size = 8
+	grid := newWalledGrid(size, 3, 4)
+
+	for fx := 0; fx < size; fx++ {
+		for fy := 0; fy < size; fy++ {
+			for tx := 0; tx < size; tx++ {
+				for ty := 0; ty < size; ty++ {
+					from, to := grid[[2]int{fx, fy}], grid[[2]int{tx, ty}]
+
+					_, wantDist, wantFound := Path(from, to)
+					_, gotDist, gotFound := PathBidirectional(nil, from, to)
+
+					if gotFound != wantFound {
+						t.Fatalf("(%d,%d)->(%d,%d): found=%v want=%v", fx, fy, tx, ty, gotFound, wantFound)
+					}
+					if wantFound && gotDist != wantDist {
+						t.Fatalf("(%d,%d)->(%d,%d): PathBidirectional dist=%v Path dist=%v", fx, fy, tx, ty, gotDist, wantDist)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPathBidirectionalUnreachable(t *testing.T) {
+	grid := newWalledGrid(4, 1, -1) // wallGapY out of range: no gap, wall is solid.
+
+	from, to := grid[[2]int{0, 0}], grid[[2]int{3, 3}]
+	_, _, found := PathBidirectional(nil, from, to)
+	if found {
+		t.Fatalf("expected no path across a solid wall")
+	}
+}
@@ -0,0 +1,56 @@
+package astar
+
+import "testing"
+
+func TestPathWithBudgetReachable(t *testing.T) {
+	grid := newOpenGrid(6)
+
+	from, to := grid[[2]int{0, 0}], grid[[2]int{5, 5}]
+	wantPath, wantDist, wantFound := Path(from, to)
+	if !wantFound {
+		t.Fatalf("expected Path to find a route on an unwalled grid")
+	}
+
+	path, dist, found := PathWithBudget(nil, from, to, 20)
+	if !found {
+		t.Fatalf("expected path within a generous budget")
+	}
+	if dist != wantDist || len(path) != len(wantPath) {
+		t.Fatalf("got dist=%v len=%d, want dist=%v len=%d", dist, len(path), wantDist, len(wantPath))
+	}
+}
+
+func TestPathWithBudgetFallsBackToClosestNode(t *testing.T) {
+	grid := newOpenGrid(6)
+
+	from, to := grid[[2]int{0, 0}], grid[[2]int{5, 5}]
+	path, _, found := PathWithBudget(nil, from, to, 3)
+
+	if found {
+		t.Fatalf("expected budget to be exhausted before reaching the goal")
+	}
+	if len(path) == 0 {
+		t.Fatalf("expected a fallback path to the closest reached node, got none")
+	}
+
+	closest := path[0]
+	if closest.PathEstimatedCost(nil, to) >= from.PathEstimatedCost(nil, to) {
+		t.Fatalf("fallback path did not make progress toward the goal")
+	}
+}
+
+func TestSearchBestTracksClosestNode(t *testing.T) {
+	grid := newOpenGrid(6)
+
+	from, to := grid[[2]int{0, 0}], grid[[2]int{5, 5}]
+	s := NewSearch(nil, from, to)
+	s.budgeted = true
+	s.maxCost = 3
+	for !s.Step() {
+	}
+
+	best := s.Best()
+	if best == from {
+		t.Fatalf("expected Best to have advanced past the start node")
+	}
+}
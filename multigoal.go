@@ -0,0 +1,160 @@
+package astar
+
+import (
+	"container/heap"
+	"math"
+)
+
+// multiGoalResult is the result of a MultiGoalSearch.
+type multiGoalResult struct {
+	path        []Pather
+	distance    float64
+	goal        Pather
+	found, done bool
+}
+
+// MultiGoalSearch is an object representing the current state of a search
+// for the nearest of several goal nodes - the nearest exit, enemy or
+// resource, say. The heuristic is the minimum estimated cost to any goal,
+// so a single frontier prunes far more aggressively than running one
+// Search per goal and taking the minimum.
+type MultiGoalSearch struct {
+	nm       nodeMap
+	nq       *priorityQueue
+	tmp      []Pather
+	fromNode *node
+	goals    map[Pather]bool
+	goalList []Pather
+	ctx      Context
+	res      multiGoalResult
+}
+
+// NewMultiGoalSearch creates a new multi-goal search object. Like NewSearch,
+// its nodeMap, priority queue and nodes are drawn from the package-scope
+// pools; call Release once the result has been consumed to return them.
+func NewMultiGoalSearch(ctx Context, from Pather, goals []Pather) *MultiGoalSearch {
+	s := &MultiGoalSearch{}
+	s.nm = nodeMapPool.Get().(nodeMap)
+	s.nq = queuePool.Get().(*priorityQueue)
+	s.tmp = make([]Pather, 0, 8)
+	heap.Init(s.nq)
+	s.goalList = goals
+	s.goals = make(map[Pather]bool, len(goals))
+	for _, g := range goals {
+		s.goals[g] = true
+	}
+	s.fromNode = s.nm.get(from)
+	s.fromNode.open = true
+	s.fromNode.rank = minEstimatedCost(ctx, from, s.goalList)
+	heap.Push(s.nq, s.fromNode)
+	s.ctx = ctx
+	return s
+}
+
+// PathToAny calculates a short path and the distance from "from" to the
+// nearest of goals, along with which goal was reached. If none of the goals
+// are reachable, found will be false.
+func PathToAny(ctx Context, from Pather, goals []Pather) (path []Pather, distance float64, goal Pather, found bool) {
+	s := NewMultiGoalSearch(ctx, from, goals)
+	for !s.Step() {
+	}
+	path, distance, goal, found = s.Result()
+	s.Release()
+	return
+}
+
+// Result retrieves the final search result.
+func (s *MultiGoalSearch) Result() (path []Pather, distance float64, goal Pather, found bool) {
+	for !s.Step() {
+	}
+	return s.res.path, s.res.distance, s.res.goal, s.res.found
+}
+
+// Release returns the search's nodeMap, priority queue and nodes to their
+// package pools so a later search can reuse them instead of allocating
+// fresh ones. Call it once Result (or Step until done) has been consumed;
+// the search must not be used afterwards.
+func (s *MultiGoalSearch) Release() {
+	if s.nm == nil {
+		return
+	}
+
+	for p, n := range s.nm {
+		nodePool.Put(n)
+		delete(s.nm, p)
+	}
+	nodeMapPool.Put(s.nm)
+	s.nm = nil
+
+	*s.nq = (*s.nq)[:0]
+	queuePool.Put(s.nq)
+	s.nq = nil
+}
+
+// Step advances the search.
+//
+// Returns true if the search is done.
+func (s *MultiGoalSearch) Step() bool {
+	if s.res.done || s.nq.Len() == 0 {
+		// There's no path to any goal, or we are already done.
+		s.res.done = true
+		return s.res.done
+	}
+
+	current := heap.Pop(s.nq).(*node)
+	current.open = false
+	current.closed = true
+
+	if s.goals[current.pather] {
+		// Found a path to the nearest goal. An earlier goal may have
+		// been pushed onto the open set first, but whichever goal
+		// pops with the lowest rank is the nearest one. Mark the
+		// search done so a stray extra Step (Result loops until Step
+		// reports done) can't keep going and reach a farther goal.
+		s.res.done = true
+		s.res.path, s.res.distance = pathTo(current)
+		s.res.goal = current.pather
+		s.res.found = true
+
+		return true
+	}
+
+	for _, neighbor := range current.pather.PathNeighbors(s.ctx, s.tmp[:0]) {
+		cost := current.cost + current.pather.PathNeighborCost(s.ctx, neighbor)
+		neighborNode := s.nm.get(neighbor)
+		if cost < neighborNode.cost {
+			if neighborNode.open {
+				heap.Remove(s.nq, neighborNode.index)
+			}
+			neighborNode.open = false
+			neighborNode.closed = false
+		}
+		if !neighborNode.open && !neighborNode.closed {
+			neighborNode.cost = cost
+			neighborNode.open = true
+			neighborNode.rank = cost + minEstimatedCost(s.ctx, neighbor, s.goalList)
+			neighborNode.parent = current
+			heap.Push(s.nq, neighborNode)
+		}
+	}
+
+	return false
+}
+
+// minEstimatedCost returns the smallest heuristic distance from "from" to
+// any of goals, which keeps the combined heuristic admissible across the
+// whole goal set.
+func minEstimatedCost(ctx Context, from Pather, goals []Pather) float64 {
+	best := math.Inf(1)
+	for _, g := range goals {
+		if c := from.PathEstimatedCost(ctx, g); c < best {
+			best = c
+		}
+	}
+	return best
+}
+
+// Context returns the users search context.
+func (s *MultiGoalSearch) Context() Context {
+	return s.ctx
+}
@@ -0,0 +1,109 @@
+package astar
+
+import "time"
+
+// SearchOptions configures a weighted, expansion-bounded and optionally
+// anytime search created with NewSearchWithOptions or PathWithOptions.
+type SearchOptions struct {
+	// HeuristicWeight scales the heuristic term of the rank computation:
+	// rank = cost + HeuristicWeight*PathEstimatedCost(...). The zero value
+	// behaves as 1 (plain A*); values above 1 trade optimality for speed
+	// (Weighted A*).
+	HeuristicWeight float64
+
+	// MaxExpansions stops the search after this many nodes have been
+	// popped off the open set, returning the best path found so far
+	// instead of continuing to explore the whole graph. Zero means
+	// unlimited.
+	MaxExpansions int
+
+	// Anytime turns on anytime search: once a first, possibly suboptimal,
+	// solution is found at HeuristicWeight, the search keeps refining it
+	// with a shrinking weight - calling OnImprove every time a cheaper
+	// path is found - until it reaches the optimal solution (weight 1),
+	// the open set is exhausted, or Deadline passes. Each refinement is a
+	// fresh search at the lower weight rather than a resumption of the
+	// previous frontier (unlike ARA*), which is simpler at the cost of
+	// redoing some of the earlier search's work.
+	Anytime bool
+
+	// OnImprove is called with each improved path while Anytime is
+	// running. It is never called for the first solution found; compare
+	// against that via the return value of PathWithOptions instead.
+	OnImprove func(path []Pather, distance float64)
+
+	// Deadline, if non-zero, stops anytime refinement once passed. The
+	// first solution found is always returned regardless of Deadline.
+	Deadline time.Time
+}
+
+// NewSearchWithOptions creates a new search object configured by opts. See
+// SearchOptions for the available knobs. Anytime refinement is driven by
+// PathWithOptions, not by Step/Result directly.
+func NewSearchWithOptions(ctx Context, from, to Pather, opts SearchOptions) *Search {
+	s := NewSearch(ctx, from, to)
+	if opts.HeuristicWeight == 0 {
+		opts.HeuristicWeight = 1
+	}
+	s.weight = opts.HeuristicWeight
+	s.maxExpansions = opts.MaxExpansions
+	return s
+}
+
+// weightEpsilon is how close to 1 the anytime weight has to get before
+// PathWithOptions treats the search as having reached the optimal solution.
+const weightEpsilon = 1e-6
+
+// PathWithOptions calculates a path configured by opts. With opts.Anytime
+// unset this is equivalent to PathWithBudget/PathWithContext but with a
+// tunable heuristic weight and expansion cap. With opts.Anytime set, the
+// first solution is found fast at opts.HeuristicWeight and then refined with
+// a shrinking weight until it is optimal, the open set is exhausted, or
+// opts.Deadline passes; opts.OnImprove is called for every improvement. Each
+// refinement step restarts the search from scratch at the new weight rather
+// than resuming the previous frontier.
+func PathWithOptions(ctx Context, from, to Pather, opts SearchOptions) (path []Pather, distance float64, found bool) {
+	if opts.HeuristicWeight == 0 {
+		opts.HeuristicWeight = 1
+	}
+
+	s := NewSearchWithOptions(ctx, from, to, opts)
+	for !s.Step() {
+	}
+	path, distance, found = s.Result()
+	s.Release()
+
+	if !opts.Anytime || !found {
+		return
+	}
+
+	for w := opts.HeuristicWeight; w > 1; {
+		if !opts.Deadline.IsZero() && time.Now().After(opts.Deadline) {
+			break
+		}
+
+		w = 1 + (w-1)/2
+		if w < 1+weightEpsilon {
+			w = 1
+		}
+
+		refine := opts
+		refine.HeuristicWeight = w
+		refine.Anytime = false
+
+		s = NewSearchWithOptions(ctx, from, to, refine)
+		for !s.Step() {
+		}
+		p, d, ok := s.Result()
+		s.Release()
+
+		if ok && d < distance {
+			path, distance = p, d
+			if opts.OnImprove != nil {
+				opts.OnImprove(path, distance)
+			}
+		}
+	}
+
+	return
+}
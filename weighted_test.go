@@ -0,0 +1,56 @@
+package astar
+
+import "testing"
+
+func TestPathWithOptionsAnytimeReachesOptimal(t *testing.T) {
+	grid := newWalledGrid(10, 4, 6)
+	from, to := grid[[2]int{0, 0}], grid[[2]int{9, 9}]
+
+	_, wantDist, wantFound := Path(from, to)
+	if !wantFound {
+		t.Fatalf("expected Path to find a route")
+	}
+
+	var improvements []float64
+	path, dist, found := PathWithOptions(nil, from, to, SearchOptions{
+		HeuristicWeight: 8,
+		Anytime:         true,
+		OnImprove: func(path []Pather, distance float64) {
+			improvements = append(improvements, distance)
+		},
+	})
+
+	if !found {
+		t.Fatalf("expected anytime search to find a path")
+	}
+	if dist != wantDist || len(path) == 0 {
+		t.Fatalf("anytime search did not converge to optimal: got dist=%v, want %v", dist, wantDist)
+	}
+
+	for i := 1; i < len(improvements); i++ {
+		if improvements[i] > improvements[i-1] {
+			t.Fatalf("OnImprove distances must be non-increasing, got %v", improvements)
+		}
+	}
+}
+
+func TestPathWithOptionsOnImproveSkipsFirstSolution(t *testing.T) {
+	grid := newOpenGrid(4)
+	from, to := grid[[2]int{0, 0}], grid[[2]int{3, 3}]
+
+	var calls int
+	_, _, found := PathWithOptions(nil, from, to, SearchOptions{
+		HeuristicWeight: 1,
+		Anytime:         true,
+		OnImprove: func(path []Pather, distance float64) {
+			calls++
+		},
+	})
+
+	if !found {
+		t.Fatalf("expected a path on an open grid")
+	}
+	if calls != 0 {
+		t.Fatalf("OnImprove must not be called when the first solution is already optimal, got %d calls", calls)
+	}
+}